@@ -0,0 +1,204 @@
+package oops
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Category is a coarse-grained classification of an error, analogous to a
+// gRPC status code, used to derive sensible HTTP status codes and to let
+// callers do `errors.Is(err, codes.NotFound)` without a giant switch.
+type Category string
+
+const (
+	Invalid           Category = "invalid"
+	NotFound          Category = "not_found"
+	Conflict          Category = "conflict"
+	Internal          Category = "internal"
+	Unavailable       Category = "unavailable"
+	PermissionDenied  Category = "permission_denied"
+	Unauthenticated   Category = "unauthenticated"
+	ResourceExhausted Category = "resource_exhausted"
+	Canceled          Category = "canceled"
+	DeadlineExceeded  Category = "deadline_exceeded"
+)
+
+var categoryHTTPStatus = map[Category]int{
+	Invalid:           400,
+	Unauthenticated:   401,
+	PermissionDenied:  403,
+	NotFound:          404,
+	Canceled:          499,
+	Conflict:          409,
+	ResourceExhausted: 429,
+	Internal:          500,
+	Unavailable:       503,
+	DeadlineExceeded:  504,
+}
+
+// Code is a registered, typed error identifier. It implements error so it
+// can be compared against an error chain with errors.Is.
+type Code struct {
+	id       string
+	category Category
+	status   int
+}
+
+func (c Code) Error() string {
+	return c.id
+}
+
+func (c Code) Category() Category {
+	return c.category
+}
+
+var (
+	codeRegistryMu sync.RWMutex
+	codeRegistry   = map[string]Code{}
+)
+
+// RegisterCode registers a Code under the given category, once, at startup.
+// An optional httpStatus overrides the category's default HTTP status.
+func RegisterCode(id string, category Category, httpStatus ...int) Code {
+	code := Code{id: id, category: category}
+	if len(httpStatus) > 0 {
+		code.status = httpStatus[0]
+	}
+
+	codeRegistryMu.Lock()
+	codeRegistry[id] = code
+	codeRegistryMu.Unlock()
+
+	return code
+}
+
+func lookupCode(id string) (Code, bool) {
+	codeRegistryMu.RLock()
+	defer codeRegistryMu.RUnlock()
+
+	code, ok := codeRegistry[id]
+	return code, ok
+}
+
+// Category returns the category of the deepest registered code in the chain.
+func (o OopsError) Category() Category {
+	code, ok := lookupCode(o.Code())
+	if !ok {
+		return ""
+	}
+
+	return code.category
+}
+
+// HTTPStatus returns the HTTP status mapped from the deepest registered code
+// in the chain, falling back to 500 when no code is registered.
+func (o OopsError) HTTPStatus() int {
+	code, ok := lookupCode(o.Code())
+	if !ok {
+		return 500
+	}
+
+	if code.status != 0 {
+		return code.status
+	}
+
+	if status, ok := categoryHTTPStatus[code.category]; ok {
+		return status
+	}
+
+	return 500
+}
+
+// Is reports whether target is an oops.Code matching the code of any error
+// in the chain, so callers can do errors.Is(err, codes.NotFound).
+func (o OopsError) Is(target error) bool {
+	code, ok := target.(Code)
+	if !ok {
+		return false
+	}
+
+	found := false
+	recursive(o, func(e OopsError) {
+		if e.code == code.id {
+			found = true
+		}
+	})
+
+	return found
+}
+
+// safeHTTPCategories is an allowlist of categories whose stacktrace,
+// context and user fields are safe to expose to API clients. Anything not
+// on the list - including an error with no registered code at all - fails
+// closed and has those fields stripped from AsHTTP's body.
+var safeHTTPCategories = map[Category]bool{
+	Invalid:           true,
+	NotFound:          true,
+	Conflict:          true,
+	PermissionDenied:  true,
+	Unauthenticated:   true,
+	ResourceExhausted: true,
+	Canceled:          true,
+	DeadlineExceeded:  true,
+}
+
+// AsHTTP maps an error to an HTTP status and response body, reusing ToMap
+// and stripping fields that shouldn't reach API clients unless the deepest
+// code's category is explicitly allowlisted as safe to expose.
+func AsHTTP(err error) (int, map[string]any) {
+	if err == nil {
+		return 200, map[string]any{}
+	}
+
+	oopsErr, ok := AsOops(err)
+	if !ok {
+		return 500, map[string]any{"error": err.Error()}
+	}
+
+	body := oopsErr.ToMap()
+	status := oopsErr.HTTPStatus()
+
+	if !safeHTTPCategories[oopsErr.Category()] {
+		delete(body, "stacktrace")
+		delete(body, "context")
+		delete(body, "user")
+	}
+
+	return status, body
+}
+
+// OopsErrorBuilder accumulates fields before producing a terminal error via
+// Wrap or Errorf.
+type OopsErrorBuilder struct {
+	err OopsError
+	ctx context.Context
+}
+
+// CodeV sets a registered Code on the builder, deriving the free-form code
+// string from it.
+func (b OopsErrorBuilder) CodeV(code Code) OopsErrorBuilder {
+	b.err.code = code.id
+	return b
+}
+
+func (b OopsErrorBuilder) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	b.applyContext()
+	b.err.err = err
+	b.err.time = time.Now()
+
+	return b.err
+}
+
+func (b OopsErrorBuilder) Errorf(format string, args ...any) error {
+	b.applyContext()
+	b.err.msg = fmt.Sprintf(format, args...)
+	b.err.time = time.Now()
+
+	return b.err
+}
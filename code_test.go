@@ -0,0 +1,81 @@
+package oops
+
+import "testing"
+
+func TestAsHTTP(t *testing.T) {
+	RegisterCode("test_not_found", NotFound)
+	RegisterCode("test_internal", Internal)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantSafe   bool
+	}{
+		{
+			name:       "registered safe category keeps context/user",
+			err:        OopsError{msg: "missing", code: "test_not_found", context: map[string]any{"id": "1"}, userID: "user-1"},
+			wantStatus: 404,
+			wantSafe:   true,
+		},
+		{
+			name:       "registered sensitive category strips context/user",
+			err:        OopsError{msg: "boom", code: "test_internal", context: map[string]any{"secret": "value123"}, userID: "user-42"},
+			wantStatus: 500,
+			wantSafe:   false,
+		},
+		{
+			name:       "unregistered code fails closed",
+			err:        OopsError{msg: "boom", context: map[string]any{"secret": "value123"}, userID: "user-42"},
+			wantStatus: 500,
+			wantSafe:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := AsHTTP(tt.err)
+
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+
+			_, hasContext := body["context"]
+			_, hasUser := body["user"]
+
+			if hasContext != tt.wantSafe || hasUser != tt.wantSafe {
+				t.Errorf("body = %v, wantSafe %v", body, tt.wantSafe)
+			}
+		})
+	}
+}
+
+func TestAsHTTPNilError(t *testing.T) {
+	status, body := AsHTTP(nil)
+
+	if status != 200 {
+		t.Errorf("status = %d, want 200", status)
+	}
+
+	if len(body) != 0 {
+		t.Errorf("body = %v, want empty", body)
+	}
+}
+
+func TestAsHTTPNonOopsError(t *testing.T) {
+	status, body := AsHTTP(errPlain("boom"))
+
+	if status != 500 {
+		t.Errorf("status = %d, want 500", status)
+	}
+
+	if body["error"] != "boom" {
+		t.Errorf("body = %v, want error=boom", body)
+	}
+}
+
+type errPlain string
+
+func (e errPlain) Error() string {
+	return string(e)
+}
@@ -0,0 +1,134 @@
+package oops
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// canceledCode and deadlineExceededCode are the standard codes applied when
+// an error is produced against a canceled or timed-out context, so
+// Category()/HTTPStatus() report the right thing even if the caller didn't
+// set a code of their own.
+var (
+	canceledCode         = RegisterCode("canceled", Canceled)
+	deadlineExceededCode = RegisterCode("deadline_exceeded", DeadlineExceeded)
+)
+
+// CtxValues are the oops fields threaded through a context.Context, set
+// once upstream via WithContext and picked up by every builder downstream
+// that calls .WithContext(ctx).
+type CtxValues struct {
+	UserID   string
+	UserData map[string]any
+	Tenant   string
+	Tags     []string
+}
+
+type ctxValuesKey struct{}
+
+// WithContext returns a child context carrying values, so that any oops
+// builder built from it (or a descendant of it) picks them up automatically.
+func WithContext(ctx context.Context, values CtxValues) context.Context {
+	return context.WithValue(ctx, ctxValuesKey{}, values)
+}
+
+// ContextValuesFunc lets applications extract their own request-scoped
+// values, e.g. a request id stored under a private ctx key, once at
+// startup. It's consulted in addition to values set via WithContext.
+var ContextValuesFunc func(ctx context.Context) CtxValues
+
+func valuesFromContext(ctx context.Context) CtxValues {
+	merged, _ := ctx.Value(ctxValuesKey{}).(CtxValues)
+
+	if ContextValuesFunc == nil {
+		return merged
+	}
+
+	extracted := ContextValuesFunc(ctx)
+
+	if extracted.UserID != "" {
+		merged.UserID = extracted.UserID
+	}
+
+	if extracted.Tenant != "" {
+		merged.Tenant = extracted.Tenant
+	}
+
+	if len(extracted.UserData) > 0 {
+		merged.UserData = lo.Assign(map[string]any{}, merged.UserData, extracted.UserData)
+	}
+
+	if len(extracted.Tags) > 0 {
+		merged.Tags = append(append([]string{}, merged.Tags...), extracted.Tags...)
+	}
+
+	return merged
+}
+
+// FromContext starts a builder pre-populated from ctx, equivalent to
+// OopsErrorBuilder{}.WithContext(ctx).
+func FromContext(ctx context.Context) OopsErrorBuilder {
+	return OopsErrorBuilder{}.WithContext(ctx)
+}
+
+// WithContext attaches ctx to the builder so that Wrap and Errorf pull
+// trace id, span id, user id, tenant, request-scoped tags and
+// deadline/cancellation state from it.
+func (b OopsErrorBuilder) WithContext(ctx context.Context) OopsErrorBuilder {
+	b.ctx = ctx
+	return b
+}
+
+func (b *OopsErrorBuilder) applyContext() {
+	if b.ctx == nil {
+		return
+	}
+
+	if sc := trace.SpanContextFromContext(b.ctx); sc.IsValid() {
+		if b.err.trace == "" {
+			b.err.trace = sc.TraceID().String()
+		}
+
+		if b.err.span == "" {
+			b.err.span = sc.SpanID().String()
+		}
+	}
+
+	values := valuesFromContext(b.ctx)
+
+	if values.UserID != "" && b.err.userID == "" {
+		b.err.userID = values.UserID
+	}
+
+	if len(values.UserData) > 0 {
+		b.err.userData = lo.Assign(map[string]any{}, values.UserData, b.err.userData)
+	}
+
+	if values.Tenant != "" {
+		b.err.context = lo.Assign(map[string]any{"tenant": values.Tenant}, b.err.context)
+	}
+
+	if len(values.Tags) > 0 {
+		b.err.tags = append(b.err.tags, values.Tags...)
+	}
+
+	switch deadline, hasDeadline := b.ctx.Deadline(); {
+	case hasDeadline && errors.Is(b.ctx.Err(), context.DeadlineExceeded):
+		b.err.duration = time.Since(deadline)
+		b.err.tags = append(b.err.tags, "deadline_exceeded")
+
+		if b.err.code == "" {
+			b.err.code = deadlineExceededCode.id
+		}
+	case errors.Is(b.ctx.Err(), context.Canceled):
+		b.err.tags = append(b.err.tags, "canceled")
+
+		if b.err.code == "" {
+			b.err.code = canceledCode.id
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package oops
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestApplyContextDeadlineExceeded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	<-ctx.Done()
+
+	b := OopsErrorBuilder{}.WithContext(ctx)
+	b.applyContext()
+
+	if !containsTag(b.err.tags, "deadline_exceeded") {
+		t.Errorf("expected deadline_exceeded tag, got %v", b.err.tags)
+	}
+
+	if containsTag(b.err.tags, "canceled") {
+		t.Errorf("did not expect canceled tag, got %v", b.err.tags)
+	}
+
+	if b.err.duration < 0 {
+		t.Errorf("expected non-negative duration, got %s", b.err.duration)
+	}
+}
+
+func TestApplyContextManualCancelBeforeDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	cancel()
+
+	b := OopsErrorBuilder{}.WithContext(ctx)
+	b.applyContext()
+
+	if !containsTag(b.err.tags, "canceled") {
+		t.Errorf("expected canceled tag, got %v", b.err.tags)
+	}
+
+	if containsTag(b.err.tags, "deadline_exceeded") {
+		t.Errorf("did not expect deadline_exceeded tag for a manual cancel, got %v", b.err.tags)
+	}
+
+	if b.err.code != canceledCode.id {
+		t.Errorf("expected canceled code, got %q", b.err.code)
+	}
+}
+
+func TestApplyContextPlainCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b := OopsErrorBuilder{}.WithContext(ctx)
+	b.applyContext()
+
+	if !containsTag(b.err.tags, "canceled") {
+		t.Errorf("expected canceled tag, got %v", b.err.tags)
+	}
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -11,8 +11,6 @@ import (
 	"golang.org/x/exp/slog"
 )
 
-var SourceFragmentsHidden = true
-
 type OopsError struct {
 	err      error
 	msg      string
@@ -102,12 +100,12 @@ func (o OopsError) Tags() []string {
 }
 
 func (o OopsError) Context() map[string]any {
-	return mergeNestedErrorMap(
+	return redactMap(mergeNestedErrorMap(
 		o,
 		func(e OopsError) map[string]any {
 			return e.context
 		},
-	)
+	))
 }
 
 func (o OopsError) Trace() string {
@@ -162,7 +160,7 @@ func (o OopsError) User() (string, map[string]any) {
 		},
 	)
 
-	return userID, userData
+	return redactString("id", userID), redactMap(userData)
 }
 
 func (o OopsError) Stacktrace() string {
@@ -171,7 +169,7 @@ func (o OopsError) Stacktrace() string {
 
 	recursive(o, func(e OopsError) {
 		if e.stacktrace != nil && len(e.stacktrace.frames) > 0 {
-			msg := coalesceOrEmpty(e.msg, "Error")
+			msg := coalesceOrEmpty(redactString("message", e.msg), "Error")
 			block := fmt.Sprintf("%s\n%s", msg, e.stacktrace.String(topFrame))
 
 			blocks = append([]string{block}, blocks...)
@@ -195,7 +193,7 @@ func (o OopsError) Sources() string {
 			header, body := e.stacktrace.Source()
 
 			if e.msg != "" {
-				header = fmt.Sprintf("%s\n%s", e.msg, header)
+				header = fmt.Sprintf("%s\n%s", redactString("message", e.msg), header)
 			}
 
 			if header != "" && len(body) > 0 {
@@ -220,10 +218,10 @@ func (o OopsError) Sources() string {
 }
 
 func (o OopsError) LogValuer() slog.Value {
-	attrs := []slog.Attr{slog.String("message", o.msg)}
+	attrs := []slog.Attr{slog.String("message", redactString("message", o.msg))}
 
 	if err := o.Error(); err != "" {
-		attrs = append(attrs, slog.String("err", err))
+		attrs = append(attrs, slog.String("err", redactString("err", err)))
 	}
 
 	if code := o.Code(); code != "" {
@@ -255,7 +253,7 @@ func (o OopsError) LogValuer() slog.Value {
 	// }
 
 	if hint := o.Hint(); hint != "" {
-		attrs = append(attrs, slog.String("hint", hint))
+		attrs = append(attrs, slog.String("hint", redactString("hint", hint)))
 	}
 
 	if owner := o.Owner(); owner != "" {
@@ -294,7 +292,7 @@ func (o OopsError) LogValuer() slog.Value {
 		attrs = append(attrs, slog.String("stacktrace", stacktrace))
 	}
 
-	if sources := o.Sources(); sources != "" && !SourceFragmentsHidden {
+	if sources := o.Sources(); sources != "" && !Privacy.HideSourceFragments {
 		attrs = append(attrs, slog.String("sources", sources))
 	}
 
@@ -305,7 +303,7 @@ func (o OopsError) ToMap() map[string]any {
 	payload := map[string]any{}
 
 	if err := o.Error(); err != "" {
-		payload["error"] = err
+		payload["error"] = redactString("error", err)
 	}
 
 	if code := o.Code(); code != "" {
@@ -341,7 +339,7 @@ func (o OopsError) ToMap() map[string]any {
 	// }
 
 	if hint := o.Hint(); hint != "" {
-		payload["hint"] = hint
+		payload["hint"] = redactString("hint", hint)
 	}
 
 	if owner := o.Owner(); owner != "" {
@@ -361,7 +359,7 @@ func (o OopsError) ToMap() map[string]any {
 		payload["stacktrace"] = stacktrace
 	}
 
-	if sources := o.Sources(); sources != "" && !SourceFragmentsHidden {
+	if sources := o.Sources(); sources != "" && !Privacy.HideSourceFragments {
 		payload["sources"] = sources
 	}
 
@@ -381,7 +379,7 @@ func (o OopsError) Format(s fmt.State, verb rune) {
 }
 
 func (o *OopsError) formatVerbose() string {
-	output := fmt.Sprintf("Oops: %s\n", o.Error())
+	output := fmt.Sprintf("Oops: %s\n", redactString("error", o.Error()))
 
 	if code := o.Code(); code != "" {
 		output += fmt.Sprintf("Code: %s\n", code)
@@ -412,7 +410,7 @@ func (o *OopsError) formatVerbose() string {
 	// }
 
 	if hint := o.Hint(); hint != "" {
-		output += fmt.Sprintf("Hint: %s\n", hint)
+		output += fmt.Sprintf("Hint: %s\n", redactString("hint", hint))
 	}
 
 	if owner := o.Owner(); owner != "" {
@@ -444,7 +442,7 @@ func (o *OopsError) formatVerbose() string {
 		output += fmt.Sprintf("Stackstrace:\n%s\n", stacktrace)
 	}
 
-	if sources := o.Sources(); sources != "" && !SourceFragmentsHidden {
+	if sources := o.Sources(); sources != "" && !Privacy.HideSourceFragments {
 		output += fmt.Sprintf("Sources:\n%s\n", sources)
 	}
 
@@ -0,0 +1,95 @@
+package oops
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordOnSpan walks the error chain and records each layer as a span event
+// on the span extracted from ctx, then marks the span status as an error.
+func (o OopsError) RecordOnSpan(ctx context.Context) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	stacktrace := ""
+
+	recursive(o, func(e OopsError) {
+		span.AddEvent("exception", trace.WithAttributes(e.otelAttributes()...))
+
+		if e.stacktrace != nil && len(e.stacktrace.frames) > 0 {
+			stacktrace = e.Stacktrace()
+		}
+	})
+
+	if stacktrace != "" {
+		span.SetAttributes(attribute.String("exception.stacktrace", stacktrace))
+	}
+
+	span.SetStatus(codes.Error, redactString("error", o.Error()))
+}
+
+// RecordError is a convenience wrapper around OopsError.RecordOnSpan for
+// errors that may or may not have been built with oops.
+func RecordError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	oopsErr, ok := AsOops(err)
+	if !ok {
+		trace.SpanFromContext(ctx).RecordError(err)
+		return
+	}
+
+	oopsErr.RecordOnSpan(ctx)
+}
+
+func (o OopsError) otelAttributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("exception.message", redactString("error", o.Error())),
+	}
+
+	if o.msg != "" {
+		attrs = append(attrs, attribute.String("oops.message", redactString("message", o.msg)))
+	}
+
+	if o.code != "" {
+		attrs = append(attrs, attribute.String("oops.code", o.code))
+	}
+
+	if o.domain != "" {
+		attrs = append(attrs, attribute.String("oops.domain", o.domain))
+	}
+
+	if o.trace != "" {
+		attrs = append(attrs, attribute.String("oops.trace", o.trace))
+	}
+
+	if len(o.tags) > 0 {
+		attrs = append(attrs, attribute.StringSlice("oops.tags", o.tags))
+	}
+
+	if o.hint != "" {
+		attrs = append(attrs, attribute.String("oops.hint", redactString("hint", o.hint)))
+	}
+
+	if o.owner != "" {
+		attrs = append(attrs, attribute.String("oops.owner", o.owner))
+	}
+
+	if o.userID != "" {
+		attrs = append(attrs, attribute.String("oops.user.id", redactString("id", o.userID)))
+	}
+
+	for k, v := range redactMap(o.context) {
+		attrs = append(attrs, attribute.String("oops.context."+k, fmt.Sprintf("%v", v)))
+	}
+
+	return attrs
+}
@@ -0,0 +1,82 @@
+package oops
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecordOnSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "op")
+
+	err := OopsError{
+		msg:    "boom",
+		code:   "test_code",
+		domain: "billing",
+		tags:   []string{"tag1"},
+		hint:   "check the input",
+		owner:  "team-x",
+		trace:  "trace-123",
+		userID: "user-1",
+	}
+
+	err.RecordOnSpan(ctx)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 ended span, got %d", len(spans))
+	}
+
+	events := spans[0].Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 span event, got %d", len(events))
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range events[0].Attributes {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+
+	want := map[string]string{
+		"exception.message": "boom",
+		"oops.message":      "boom",
+		"oops.code":         "test_code",
+		"oops.domain":       "billing",
+		"oops.hint":         "check the input",
+		"oops.owner":        "team-x",
+		"oops.trace":        "trace-123",
+		"oops.user.id":      "user-1",
+	}
+
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("attribute %q = %q, want %q", key, attrs[key], value)
+		}
+	}
+
+	if spans[0].Status().Description != "boom" {
+		t.Errorf("span status description = %q, want %q", spans[0].Status().Description, "boom")
+	}
+}
+
+func TestRecordErrorNonOops(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "op")
+	RecordError(ctx, errPlain("plain failure"))
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || len(spans[0].Events()) != 1 {
+		t.Fatalf("expected 1 ended span with 1 event, got %+v", spans)
+	}
+}
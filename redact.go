@@ -0,0 +1,225 @@
+package oops
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// PrivacyConfig controls how much of an OopsError's payload is emitted by
+// ToMap, LogValuer, MarshalJSON and the %+v formatter.
+type PrivacyConfig struct {
+	// HideSourceFragments hides the source code snippets captured alongside
+	// the stacktrace. Defaults to true since they're the most likely place
+	// to leak application internals.
+	HideSourceFragments bool
+
+	// RedactPatterns enables the built-in pattern redactors (email, bearer
+	// tokens, JWTs, credit card numbers, IP addresses) on the error and
+	// message text, the hint, the user id and the string values of the
+	// context and user data maps before they're emitted. Code, domain,
+	// tags, trace and owner are emitted as they are.
+	RedactPatterns bool
+}
+
+// Privacy is the global privacy configuration, replacing the old
+// SourceFragmentsHidden flag.
+var Privacy = PrivacyConfig{
+	HideSourceFragments: true,
+	RedactPatterns:      true,
+}
+
+// Redactor inspects a key/value pair about to be emitted and optionally
+// replaces it. The bool return reports whether a replacement was made.
+type Redactor interface {
+	Redact(key string, value any) (any, bool)
+}
+
+// redactDropped is returned by a Redactor, or looked up from a key rule, to
+// signal that the key should be omitted entirely rather than masked.
+var redactDropped = &struct{}{}
+
+type RedactAction int
+
+const (
+	RedactMask RedactAction = iota
+	RedactDrop
+)
+
+var (
+	redactorsMu sync.RWMutex
+	redactors   = []Redactor{patternRedactor{}}
+
+	keyRulesMu sync.RWMutex
+	keyRules   = map[string]RedactAction{}
+)
+
+// RegisterRedactor appends a Redactor to the chain consulted by Context,
+// User, ToMap, LogValuer and the %+v formatter.
+func RegisterRedactor(r Redactor) {
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+
+	redactors = append(redactors, r)
+}
+
+// RedactKey registers a per-key rule, consulted before the pattern
+// redactors, e.g. oops.RedactKey("password", oops.RedactDrop).
+func RedactKey(key string, action RedactAction) {
+	keyRulesMu.Lock()
+	defer keyRulesMu.Unlock()
+
+	keyRules[strings.ToLower(key)] = action
+}
+
+func applyRedactors(key string, value any) (any, bool) {
+	keyRulesMu.RLock()
+	action, hasRule := keyRules[strings.ToLower(key)]
+	keyRulesMu.RUnlock()
+
+	if hasRule {
+		switch action {
+		case RedactDrop:
+			return redactDropped, true
+		case RedactMask:
+			return "***", true
+		}
+	}
+
+	redactorsMu.RLock()
+	defer redactorsMu.RUnlock()
+
+	for _, r := range redactors {
+		if rv, ok := r.Redact(key, value); ok {
+			return rv, true
+		}
+	}
+
+	return value, false
+}
+
+func redactMap(m map[string]any) map[string]any {
+	if len(m) == 0 {
+		return m
+	}
+
+	out := make(map[string]any, len(m))
+
+	for k, v := range m {
+		rv, changed := applyRedactors(k, v)
+		if !changed {
+			out[k] = v
+			continue
+		}
+
+		if rv == redactDropped {
+			continue
+		}
+
+		out[k] = rv
+	}
+
+	return out
+}
+
+func redactString(key, value string) string {
+	if value == "" {
+		return value
+	}
+
+	rv, changed := applyRedactors(key, value)
+	if !changed {
+		return value
+	}
+
+	if rv == redactDropped {
+		return ""
+	}
+
+	s, ok := rv.(string)
+	if !ok {
+		return value
+	}
+
+	return s
+}
+
+var (
+	jwtPattern    = regexp.MustCompile(`eyJ[a-zA-Z0-9_-]+\.eyJ[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+`)
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+[a-zA-Z0-9\-._~+/]+=*`)
+	emailPattern  = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	ipv6Pattern   = regexp.MustCompile(`\b([0-9a-fA-F]{1,4}:){2,7}[0-9a-fA-F]{1,4}\b`)
+	ipv4Pattern   = regexp.MustCompile(`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`)
+	digitsPattern = regexp.MustCompile(`\b[0-9][0-9 -]{11,22}[0-9]\b`)
+)
+
+// patternRedactor masks common PII patterns found inside string values:
+// JWTs, bearer tokens, emails, IP addresses and Luhn-valid card numbers.
+type patternRedactor struct{}
+
+func (patternRedactor) Redact(_ string, value any) (any, bool) {
+	if !Privacy.RedactPatterns {
+		return value, false
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return value, false
+	}
+
+	changed := false
+
+	for _, pattern := range []*regexp.Regexp{jwtPattern, bearerPattern, emailPattern, ipv6Pattern, ipv4Pattern} {
+		if pattern.MatchString(s) {
+			s = pattern.ReplaceAllString(s, "[redacted]")
+			changed = true
+		}
+	}
+
+	s = digitsPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if isLuhnValid(m) {
+			changed = true
+			return "[redacted]"
+		}
+
+		return m
+	})
+
+	if !changed {
+		return value, false
+	}
+
+	return s, true
+}
+
+func isLuhnValid(number string) bool {
+	sum := 0
+	alternate := false
+	digits := 0
+
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+
+		if c < '0' || c > '9' {
+			return false
+		}
+
+		digits++
+		n := int(c - '0')
+
+		if alternate {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+
+		sum += n
+		alternate = !alternate
+	}
+
+	return digits >= 12 && sum%10 == 0
+}
@@ -0,0 +1,68 @@
+package oops
+
+import "testing"
+
+func TestIsLuhnValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{"valid visa", "4242424242424242", true},
+		{"valid with separators", "4242-4242 4242-4242", true},
+		{"invalid checksum", "4242424242424241", false},
+		{"too short", "424242", false},
+		{"non digit", "4242-42a2-4242-4242", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLuhnValid(tt.number); got != tt.want {
+				t.Errorf("isLuhnValid(%q) = %v, want %v", tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatternRedactorRedact(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"email", "contact alice@example.com for help", "contact [redacted] for help"},
+		{"bearer token", "Authorization: Bearer abc123DEF", "Authorization: [redacted]"},
+		{"ipv4", "request from 10.0.0.1 failed", "request from [redacted] failed"},
+		{"credit card", "card 4242424242424242 declined", "card [redacted] declined"},
+		{"no match", "nothing sensitive here", "nothing sensitive here"},
+	}
+
+	r := patternRedactor{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := r.Redact("message", tt.value)
+
+			if tt.want != tt.value && !changed {
+				t.Fatalf("Redact(%q) did not report a change", tt.value)
+			}
+
+			if got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRedactorsKeyRules(t *testing.T) {
+	RedactKey("test_password", RedactDrop)
+	RedactKey("test_username", RedactMask)
+
+	if v, changed := applyRedactors("test_password", "hunter2"); !changed || v != redactDropped {
+		t.Errorf("expected test_password to be dropped, got %v, changed=%v", v, changed)
+	}
+
+	if v, changed := applyRedactors("test_username", "alice"); !changed || v != "***" {
+		t.Errorf("expected test_username to be masked, got %v, changed=%v", v, changed)
+	}
+}